@@ -1,307 +1,340 @@
-// LangExtract API — Go client example.
-//
-// Demonstrates:
-//   1. Submit an extraction from raw text.
-//   2. Submit an extraction from a URL.
-//   3. Submit a batch.
-//   4. Poll a task until it completes.
-//
-// Uses only the Go standard library — no external dependencies.
-//
-// Usage:
-//   go run examples/go/client.go
-
-package main
-
-import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
-	"net/http"
-	"os"
-	"time"
-)
-
-// ---------------------------------------------------------------------------
-// Configuration
-// ---------------------------------------------------------------------------
-
-const (
-	defaultAPIBase     = "http://localhost:8000/api/v1"
-	defaultProvider    = "gpt-4o"
-	pollIntervalSec    = 2
-	pollTimeoutSec     = 120
-)
-
-func apiBase() string {
-	if v := os.Getenv("API_BASE"); v != "" {
-		return v
-	}
-	return defaultAPIBase
-}
-
-// ---------------------------------------------------------------------------
-// Types
-// ---------------------------------------------------------------------------
-
-// ExtractionConfig holds optional LangExtract pipeline overrides.
-type ExtractionConfig struct {
-	PromptDescription string  `json:"prompt_description,omitempty"`
-	Temperature       float64 `json:"temperature,omitempty"`
-}
-
-// ExtractionRequest is the body for POST /extract.
-type ExtractionRequest struct {
-	RawText          string           `json:"raw_text,omitempty"`
-	DocumentURL      string           `json:"document_url,omitempty"`
-	Provider         string           `json:"provider,omitempty"`
-	Passes           int              `json:"passes,omitempty"`
-	IdempotencyKey   string           `json:"idempotency_key,omitempty"`
-	ExtractionConfig ExtractionConfig `json:"extraction_config,omitempty"`
-}
-
-// SubmitResponse is the body returned by POST /extract.
-type SubmitResponse struct {
-	TaskID  string `json:"task_id"`
-	Status  string `json:"status"`
-	Message string `json:"message"`
-}
-
-// BatchRequest is the body for POST /extract/batch.
-type BatchRequest struct {
-	BatchID   string              `json:"batch_id"`
-	Documents []ExtractionRequest `json:"documents"`
-	Provider  string              `json:"provider,omitempty"`
-}
-
-// BatchSubmitResponse is the body returned by POST /extract/batch.
-type BatchSubmitResponse struct {
-	BatchID     string   `json:"batch_id"`
-	TaskIDs     []string `json:"task_ids"`
-	BatchTaskID string   `json:"batch_task_id"`
-}
-
-// Entity is a single extracted entity in a completed task result.
-type Entity struct {
-	ExtractionClass string            `json:"extraction_class"`
-	ExtractionText  string            `json:"extraction_text"`
-	Attributes      map[string]string `json:"attributes,omitempty"`
-}
-
-// TaskResult holds the list of extracted entities.
-type TaskResult struct {
-	Entities []Entity `json:"entities"`
-}
-
-// TaskResponse is the body returned by GET /tasks/{id}.
-type TaskResponse struct {
-	TaskID string      `json:"task_id"`
-	State  string      `json:"state"`
-	Result *TaskResult `json:"result,omitempty"`
-	Error  string      `json:"error,omitempty"`
-}
-
-// ---------------------------------------------------------------------------
-// HTTP helpers
-// ---------------------------------------------------------------------------
-
-// postJSON marshals payload, POSTs to url, and decodes the response into dst.
-func postJSON(url string, payload, dst any) error {
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshal: %w", err)
-	}
-
-	resp, err := http.Post(url, "application/json", bytes.NewReader(body)) //nolint:noctx
-	if err != nil {
-		return fmt.Errorf("POST %s: %w", url, err)
-	}
-	defer resp.Body.Close()
-
-	raw, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, raw)
-	}
-	return json.Unmarshal(raw, dst)
-}
-
-// getJSON GETs url and decodes the response into dst.
-func getJSON(url string, dst any) error {
-	resp, err := http.Get(url) //nolint:noctx
-	if err != nil {
-		return fmt.Errorf("GET %s: %w", url, err)
-	}
-	defer resp.Body.Close()
-
-	raw, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, raw)
-	}
-	return json.Unmarshal(raw, dst)
-}
-
-// ---------------------------------------------------------------------------
-// API calls
-// ---------------------------------------------------------------------------
-
-// submitExtraction POSTs to /extract and returns the submission response.
-func submitExtraction(req ExtractionRequest) (SubmitResponse, error) {
-	var resp SubmitResponse
-	err := postJSON(apiBase()+"/extract", req, &resp)
-	return resp, err
-}
-
-// submitBatch POSTs to /extract/batch and returns the submission response.
-func submitBatch(req BatchRequest) (BatchSubmitResponse, error) {
-	var resp BatchSubmitResponse
-	err := postJSON(apiBase()+"/extract/batch", req, &resp)
-	return resp, err
-}
-
-// pollTask polls GET /tasks/{id} until state is SUCCESS or FAILURE.
-func pollTask(taskID string) (TaskResponse, error) {
-	deadline := time.Now().Add(pollTimeoutSec * time.Second)
-	for time.Now().Before(deadline) {
-		var data TaskResponse
-		if err := getJSON(apiBase()+"/tasks/"+taskID, &data); err != nil {
-			return data, err
-		}
-		fmt.Printf("  [%s…] state=%s\n", taskID[:8], data.State)
-		if data.State == "SUCCESS" || data.State == "FAILURE" {
-			return data, nil
-		}
-		time.Sleep(pollIntervalSec * time.Second)
-	}
-	return TaskResponse{}, errors.New("task did not finish within timeout")
-}
-
-// ---------------------------------------------------------------------------
-// Examples
-// ---------------------------------------------------------------------------
-
-func exampleRawText() error {
-	fmt.Println("\n── Raw text extraction ──────────────────────────")
-	submit, err := submitExtraction(ExtractionRequest{
-		RawText: "AGREEMENT dated January 15, 2025 between Acme Corporation " +
-			"(Seller) and Beta LLC (Buyer). Purchase price: $12,500 for 500 " +
-			"widgets at $25 each. Payment: net 30 days. Governed by Delaware law.",
-		Provider:       defaultProvider,
-		Passes:         1,
-		IdempotencyKey: "demo-raw-text-001",
-		ExtractionConfig: ExtractionConfig{
-			Temperature: 0.2,
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("submit: %w", err)
-	}
-	fmt.Printf("Submitted: task_id=%s\n", submit.TaskID)
-
-	final, err := pollTask(submit.TaskID)
-	if err != nil {
-		return err
-	}
-	entities := []Entity{}
-	if final.Result != nil {
-		entities = final.Result.Entities
-	}
-	fmt.Printf("Done — %d entities extracted:\n", len(entities))
-	for _, ent := range entities {
-		fmt.Printf("  [%s] %q\n", ent.ExtractionClass, ent.ExtractionText)
-	}
-	return nil
-}
-
-func exampleURL() error {
-	fmt.Println("\n── URL extraction ───────────────────────────────")
-	submit, err := submitExtraction(ExtractionRequest{
-		DocumentURL: "https://storage.example.com/contracts/agreement-2025.txt",
-		Provider:    defaultProvider,
-		ExtractionConfig: ExtractionConfig{
-			PromptDescription: "Extract any organisations, dates, and legal terms.",
-			Temperature:       0.1,
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("submit: %w", err)
-	}
-	fmt.Printf("Submitted: task_id=%s\n", submit.TaskID)
-
-	final, err := pollTask(submit.TaskID)
-	if err != nil {
-		return err
-	}
-	count := 0
-	if final.Result != nil {
-		count = len(final.Result.Entities)
-	}
-	fmt.Printf("Done — %d entities extracted.\n", count)
-	return nil
-}
-
-func exampleBatch() error {
-	fmt.Println("\n── Batch extraction ─────────────────────────────")
-	submit, err := submitBatch(BatchRequest{
-		BatchID: "demo-batch-001",
-		Documents: []ExtractionRequest{
-			{
-				RawText: "Contract A: Acme Corp sells 500 units to Beta LLC for " +
-					"$12,500. Delivery Q2 2025.",
-			},
-			{
-				RawText: "Contract B: Charlie Enterprises leases warehouse space " +
-					"from Delta Holdings at $3,200/month for 24 months.",
-			},
-			{
-				RawText: "Contract C: Echo Inc purchases software licences from " +
-					"Foxtrot SaaS Ltd at $9,000/year, auto-renewing annually.",
-			},
-		},
-		Provider: defaultProvider,
-	})
-	if err != nil {
-		return fmt.Errorf("submit batch: %w", err)
-	}
-
-	fmt.Printf("Batch submitted — %d task(s):\n", len(submit.TaskIDs))
-	for _, tid := range submit.TaskIDs {
-		fmt.Printf("  task_id=%s\n", tid)
-	}
-
-	for _, tid := range submit.TaskIDs {
-		final, err := pollTask(tid)
-		if err != nil {
-			return err
-		}
-		count := 0
-		if final.Result != nil {
-			count = len(final.Result.Entities)
-		}
-		fmt.Printf("  [%s…] finished — %d entities\n", tid[:8], count)
-	}
-	return nil
-}
-
-// ---------------------------------------------------------------------------
-// main
-// ---------------------------------------------------------------------------
-
-func main() {
-	steps := []struct {
-		name string
-		fn   func() error
-	}{
-		{"raw text", exampleRawText},
-		{"URL", exampleURL},
-		{"batch", exampleBatch},
-	}
-
-	for _, s := range steps {
-		if err := s.fn(); err != nil {
-			fmt.Fprintf(os.Stderr, "example %s failed: %v\n", s.name, err)
-			os.Exit(1)
-		}
-	}
-}
+// LangExtract API — Go client example.
+//
+// Demonstrates the pkg/client SDK:
+//   1. Submit an extraction from raw text.
+//   2. Submit a schema-driven extraction into a typed Go struct.
+//   3. Submit an extraction from a URL.
+//   4. Submit a batch.
+//   5. Stream a batch from an NDJSON file.
+//   6. Ingest a directory of files with content-addressed idempotency keys.
+//   7. Poll a task until it completes.
+//
+// Pass --metrics-addr to also expose Prometheus metrics for the client's
+// JSON RPCs (requests, poll iterations, task latency); the streaming batch
+// and multipart uploads are not counted.
+//
+// Usage:
+//   go run examples/go/client.go
+//   go run examples/go/client.go --output-format=csv --output=results.csv
+//   go run examples/go/client.go --metrics-addr=:9090
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/IgnatG/langextract-api/pkg/client"
+	"github.com/IgnatG/langextract-api/pkg/sink"
+)
+
+const defaultProvider = "gpt-4o"
+
+var (
+	outputFormat = flag.String("output-format", "", "write every completed task's entities to --output as one of: jsonl, csv, parquet")
+	outputPath   = flag.String("output", "output", "destination file for --output-format (extension is added automatically)")
+	metricsAddr  = flag.String("metrics-addr", "", "if set, serve Prometheus metrics for the client's RPCs at http://<addr>/metrics")
+)
+
+func apiBase() string {
+	if v := os.Getenv("API_BASE"); v != "" {
+		return v
+	}
+	return "http://localhost:8000/api/v1"
+}
+
+// openSink constructs the sink.Writer selected by --output-format, or nil if
+// the flag was not set. The returned file must be closed by the caller after
+// the sink itself has been closed.
+func openSink() (sink.Writer, *os.File, error) {
+	if *outputFormat == "" {
+		return nil, nil, nil
+	}
+
+	f, err := os.Create(*outputPath + "." + *outputFormat)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create output file: %w", err)
+	}
+
+	switch *outputFormat {
+	case "jsonl":
+		return sink.NewJSONLSink(f), f, nil
+	case "csv":
+		return sink.NewCSVSink(f), f, nil
+	case "parquet":
+		return sink.NewParquetSink(f), f, nil
+	default:
+		f.Close()
+		return nil, nil, fmt.Errorf("unknown --output-format %q (want jsonl, csv, or parquet)", *outputFormat)
+	}
+}
+
+func exampleRawText(ctx context.Context, c *client.Client, w sink.Writer) error {
+	fmt.Println("\n── Raw text extraction ──────────────────────────")
+	submit, err := c.Submit(ctx, client.ExtractionRequest{
+		RawText: "AGREEMENT dated January 15, 2025 between Acme Corporation " +
+			"(Seller) and Beta LLC (Buyer). Purchase price: $12,500 for 500 " +
+			"widgets at $25 each. Payment: net 30 days. Governed by Delaware law.",
+		Provider:       defaultProvider,
+		Passes:         1,
+		IdempotencyKey: "demo-raw-text-001",
+		ExtractionConfig: client.ExtractionConfig{
+			Temperature: 0.2,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	fmt.Printf("Submitted: task_id=%s\n", submit.TaskID)
+
+	final, err := c.PollTask(ctx, submit.TaskID)
+	if err != nil {
+		return err
+	}
+	entities := []client.Entity{}
+	if final.Result != nil {
+		entities = final.Result.Entities
+	}
+	fmt.Printf("Done — %d entities extracted:\n", len(entities))
+	for _, ent := range entities {
+		fmt.Printf("  [%s] %q\n", ent.ExtractionClass, ent.ExtractionText)
+	}
+	return writeToSink(w, final)
+}
+
+// contractParties is the typed result of exampleStructuredExtract, mapping
+// Go fields to extraction_class values via `langextract` struct tags.
+type contractParties struct {
+	Seller string            `langextract:"class=seller,required"`
+	Buyer  string            `langextract:"class=buyer,required"`
+	Terms  map[string]string `langextract:"class=party,attrs=role;jurisdiction"`
+}
+
+func exampleStructuredExtract(ctx context.Context, c *client.Client, _ sink.Writer) error {
+	fmt.Println("\n── Structured extraction ────────────────────────")
+	parties, err := client.Extract[contractParties](ctx, c, client.ExtractionRequest{
+		RawText: "AGREEMENT dated January 15, 2025 between Acme Corporation " +
+			"(Seller) and Beta LLC (Buyer), governed by Delaware law.",
+		Provider: defaultProvider,
+	})
+	if err != nil {
+		return fmt.Errorf("extract: %w", err)
+	}
+	fmt.Printf("Seller=%q Buyer=%q jurisdiction=%q\n", parties.Seller, parties.Buyer, parties.Terms["jurisdiction"])
+	return nil
+}
+
+func exampleURL(ctx context.Context, c *client.Client, w sink.Writer) error {
+	fmt.Println("\n── URL extraction ───────────────────────────────")
+	submit, err := c.Submit(ctx, client.ExtractionRequest{
+		DocumentURL: "https://storage.example.com/contracts/agreement-2025.txt",
+		Provider:    defaultProvider,
+		ExtractionConfig: client.ExtractionConfig{
+			PromptDescription: "Extract any organisations, dates, and legal terms.",
+			Temperature:       0.1,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	fmt.Printf("Submitted: task_id=%s\n", submit.TaskID)
+
+	final, err := c.PollTask(ctx, submit.TaskID)
+	if err != nil {
+		return err
+	}
+	count := 0
+	if final.Result != nil {
+		count = len(final.Result.Entities)
+	}
+	fmt.Printf("Done — %d entities extracted.\n", count)
+	return writeToSink(w, final)
+}
+
+func exampleBatch(ctx context.Context, c *client.Client, w sink.Writer) error {
+	fmt.Println("\n── Batch extraction ─────────────────────────────")
+	submit, err := c.SubmitBatch(ctx, client.BatchRequest{
+		BatchID: "demo-batch-001",
+		Documents: []client.ExtractionRequest{
+			{
+				RawText: "Contract A: Acme Corp sells 500 units to Beta LLC for " +
+					"$12,500. Delivery Q2 2025.",
+			},
+			{
+				RawText: "Contract B: Charlie Enterprises leases warehouse space " +
+					"from Delta Holdings at $3,200/month for 24 months.",
+			},
+			{
+				RawText: "Contract C: Echo Inc purchases software licences from " +
+					"Foxtrot SaaS Ltd at $9,000/year, auto-renewing annually.",
+			},
+		},
+		Provider: defaultProvider,
+	})
+	if err != nil {
+		return fmt.Errorf("submit batch: %w", err)
+	}
+
+	fmt.Printf("Batch submitted — %d task(s):\n", len(submit.TaskIDs))
+	for _, tid := range submit.TaskIDs {
+		fmt.Printf("  task_id=%s\n", tid)
+	}
+
+	for _, tid := range submit.TaskIDs {
+		final, err := c.PollTask(ctx, tid)
+		if err != nil {
+			return err
+		}
+		count := 0
+		if final.Result != nil {
+			count = len(final.Result.Entities)
+		}
+		fmt.Printf("  [%s…] finished — %d entities\n", tid[:8], count)
+		if err := writeToSink(w, final); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exampleBatchStream(ctx context.Context, c *client.Client, w sink.Writer) error {
+	fmt.Println("\n── Streaming batch extraction ───────────────────")
+	f, err := os.Open("examples/go/testdata/batch.ndjson")
+	if err != nil {
+		return fmt.Errorf("open batch file: %w", err)
+	}
+	defer f.Close()
+
+	results, err := c.SubmitBatchStream(ctx, f)
+	if err != nil {
+		return fmt.Errorf("submit batch stream: %w", err)
+	}
+
+	n := 0
+	for res := range results {
+		if res.Err != nil {
+			return fmt.Errorf("batch stream: %w", res.Err)
+		}
+		n++
+		count := 0
+		if res.Result != nil {
+			count = len(res.Result.Entities)
+		}
+		fmt.Printf("  [%s…] state=%s — %d entities\n", res.TaskID[:8], res.State, count)
+		if err := writeToSink(w, client.TaskResponse{TaskID: res.TaskID, State: res.State, Result: res.Result, Error: res.Error}); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Stream finished — %d task(s) reported.\n", n)
+	return nil
+}
+
+// writeToSink writes resp to w if a --output-format sink was configured.
+func writeToSink(w sink.Writer, resp client.TaskResponse) error {
+	if w == nil {
+		return nil
+	}
+	if err := w.Write(resp); err != nil {
+		return fmt.Errorf("write to sink: %w", err)
+	}
+	return nil
+}
+
+func exampleDirIngest(ctx context.Context, c *client.Client, w sink.Writer) error {
+	fmt.Println("\n── Directory ingestion ──────────────────────────")
+	progress, err := c.SubmitDir(ctx, "examples/go/testdata/corpus", "*.txt", client.ExtractionRequest{
+		Provider: defaultProvider,
+	})
+	if err != nil {
+		return fmt.Errorf("submit dir: %w", err)
+	}
+
+	for p := range progress {
+		if p.Err != nil {
+			return fmt.Errorf("submit %s: %w", p.Path, p.Err)
+		}
+		fmt.Printf("  %s -> task_id=%s\n", p.Path, p.Submit.TaskID)
+
+		final, err := c.PollTask(ctx, p.Submit.TaskID)
+		if err != nil {
+			return err
+		}
+		if err := writeToSink(w, final); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newClient constructs the Client, wiring up Prometheus metrics when
+// --metrics-addr is set.
+func newClient() *client.Client {
+	if *metricsAddr == "" {
+		return client.NewClient(apiBase())
+	}
+
+	reg := prometheus.NewRegistry()
+	mp, err := client.NewPrometheusMeterProvider(reg)
+	if err != nil {
+		log.Fatalf("new meter provider: %v", err)
+	}
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+		log.Printf("serving metrics at http://%s/metrics", *metricsAddr)
+		if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+			log.Printf("metrics server: %v", err)
+		}
+	}()
+
+	return client.NewClient(apiBase(), client.WithMeterProvider(mp), client.WithOTelHTTPTransport())
+}
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+	c := newClient()
+
+	w, f, err := openSink()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	if f != nil {
+		defer f.Close()
+	}
+
+	steps := []struct {
+		name string
+		fn   func(context.Context, *client.Client, sink.Writer) error
+	}{
+		{"raw text", exampleRawText},
+		{"structured extract", exampleStructuredExtract},
+		{"URL", exampleURL},
+		{"batch", exampleBatch},
+		{"batch stream", exampleBatchStream},
+		{"dir ingest", exampleDirIngest},
+	}
+
+	for _, s := range steps {
+		if err := s.fn(ctx, c, w); err != nil {
+			fmt.Fprintf(os.Stderr, "example %s failed: %v\n", s.name, err)
+			os.Exit(1)
+		}
+	}
+
+	if w != nil {
+		if err := w.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "close sink: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nWrote results to %s.%s\n", *outputPath, *outputFormat)
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxBatchResultLine bounds a single NDJSON line from /extract/batch/stream.
+// It is larger than the default bufio.Scanner limit (64KiB) so results with
+// large extraction payloads don't fail with bufio.ErrTooLong.
+const maxBatchResultLine = 10 << 20 // 10MiB
+
+// BatchItemResult is one line of the NDJSON response from
+// /extract/batch/stream: a single task's state as of when it was emitted.
+type BatchItemResult struct {
+	TaskID string      `json:"task_id"`
+	State  string      `json:"state"`
+	Result *TaskResult `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+
+	// Err is set when reading or decoding the stream itself failed, as
+	// opposed to the task reporting a failure. The channel is closed after
+	// an Err result.
+	Err error `json:"-"`
+}
+
+// BatchResultDecoder decodes a stream of newline-delimited BatchItemResult
+// JSON objects, such as the body of /extract/batch/stream. It wraps
+// bufio.Scanner with a raised buffer limit so large per-task results don't
+// overflow the default 64KiB line limit.
+type BatchResultDecoder struct {
+	scanner *bufio.Scanner
+}
+
+// NewBatchResultDecoder returns a BatchResultDecoder reading NDJSON from r.
+func NewBatchResultDecoder(r io.Reader) *BatchResultDecoder {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxBatchResultLine)
+	return &BatchResultDecoder{scanner: sc}
+}
+
+// Next decodes the next non-blank line. It returns ok=false with a nil error
+// once the stream is exhausted.
+func (d *BatchResultDecoder) Next() (item BatchItemResult, ok bool, err error) {
+	for d.scanner.Scan() {
+		line := bytes.TrimSpace(d.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(line, &item); err != nil {
+			return BatchItemResult{}, false, fmt.Errorf("decode batch result: %w", err)
+		}
+		return item, true, nil
+	}
+	return BatchItemResult{}, false, d.scanner.Err()
+}
+
+// SubmitBatchStream POSTs an NDJSON body (one ExtractionRequest per line) to
+// /extract/batch/stream and returns a channel of BatchItemResult delivered
+// as the server completes each task. Unlike SubmitBatch followed by
+// per-task PollTask calls, neither the request nor the response is
+// buffered in full, so a caller can pipe an arbitrarily large corpus
+// through docs with bounded memory.
+func (c *Client) SubmitBatchStream(ctx context.Context, docs io.Reader) (<-chan BatchItemResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/extract/batch/stream", docs)
+	if err != nil {
+		return nil, fmt.Errorf("POST %s/extract/batch/stream: %w", c.baseURL, err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	c.setAuthHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("POST %s/extract/batch/stream: %w", c.baseURL, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, raw)
+	}
+
+	results := make(chan BatchItemResult)
+	go func() {
+		defer close(results)
+		defer resp.Body.Close()
+
+		dec := NewBatchResultDecoder(resp.Body)
+		for {
+			item, ok, err := dec.Next()
+			if err != nil {
+				select {
+				case results <- BatchItemResult{Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			if !ok {
+				return
+			}
+			select {
+			case results <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSubmitBatchStream(t *testing.T) {
+	const ndjsonDocs = `{"raw_text":"doc one"}
+{"raw_text":"doc two"}
+{"raw_text":"doc three"}
+`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/extract/batch/stream" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+		}
+
+		sc := bufio.NewScanner(r.Body)
+		i := 0
+		for sc.Scan() {
+			i++
+			fmt.Fprintf(w, `{"task_id":"t-%d","state":"SUCCESS"}`+"\n", i)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	results, err := c.SubmitBatchStream(context.Background(), strings.NewReader(ndjsonDocs))
+	if err != nil {
+		t.Fatalf("SubmitBatchStream() error = %v", err)
+	}
+
+	var got []BatchItemResult
+	for r := range results {
+		if r.Err != nil {
+			t.Fatalf("unexpected stream error: %v", r.Err)
+		}
+		got = append(got, r)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(got), got)
+	}
+	for i, r := range got {
+		wantID := fmt.Sprintf("t-%d", i+1)
+		if r.TaskID != wantID {
+			t.Errorf("result %d TaskID = %q, want %q", i, r.TaskID, wantID)
+		}
+		if r.State != StateSuccess {
+			t.Errorf("result %d State = %q, want %q", i, r.State, StateSuccess)
+		}
+	}
+}
+
+func TestBatchResultDecoderSkipsBlankLines(t *testing.T) {
+	dec := NewBatchResultDecoder(strings.NewReader("\n{\"task_id\":\"t-1\",\"state\":\"SUCCESS\"}\n\n"))
+
+	item, ok, err := dec.Next()
+	if err != nil || !ok {
+		t.Fatalf("Next() = %+v, %v, %v", item, ok, err)
+	}
+	if item.TaskID != "t-1" {
+		t.Errorf("TaskID = %q, want t-1", item.TaskID)
+	}
+
+	_, ok, err = dec.Next()
+	if err != nil || ok {
+		t.Fatalf("Next() at EOF = %v, %v, want false, nil", ok, err)
+	}
+}
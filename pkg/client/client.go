@@ -0,0 +1,439 @@
+// Package client is a typed Go SDK for the LangExtract API.
+//
+// It wraps the HTTP surface demonstrated in examples/go/client.go behind a
+// reusable Client type: every RPC takes a context.Context for
+// deadlines/cancellation, JSON requests (Submit, SubmitBatch, GetTask) are
+// retried with exponential backoff + jitter on 429/5xx and can be
+// gzip-compressed via WithGzip, and StreamTask lets callers react
+// to task state transitions without writing their own poll loop.
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultPollTimeout  = 120 * time.Second
+	defaultMaxRetries   = 4
+	defaultRetryBase    = 250 * time.Millisecond
+	defaultRetryMax     = 5 * time.Second
+)
+
+// Client is a typed LangExtract API client.
+//
+// A Client is safe for concurrent use and should be reused across calls
+// rather than constructed per-request.
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	apiKey      string
+	bearerToken string
+	gzip        bool
+	maxRetries  int
+
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	useOTelHTTP    bool
+	telemetry      *telemetry
+}
+
+// Option configures a Client constructed via NewClient.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for requests. The default
+// is http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithAPIKey sets an `X-API-Key` header on every request.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithBearerToken sets an `Authorization: Bearer <token>` header on every
+// request. If both WithAPIKey and WithBearerToken are set, both headers are
+// sent.
+func WithBearerToken(token string) Option {
+	return func(c *Client) { c.bearerToken = token }
+}
+
+// WithGzip enables gzip compression of request bodies and sends
+// `Accept-Encoding: gzip` so the server may compress responses. Disabled by
+// default.
+func WithGzip(enabled bool) Option {
+	return func(c *Client) { c.gzip = enabled }
+}
+
+// WithMaxRetries caps the number of retry attempts for 429/5xx responses.
+// The default is 4.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) { c.maxRetries = n }
+}
+
+// WithPollInterval sets the delay between PollTask polls. The default is 2s.
+func WithPollInterval(d time.Duration) Option {
+	return func(c *Client) { c.pollInterval = d }
+}
+
+// WithPollTimeout bounds how long PollTask will wait for a terminal state.
+// The default is 120s.
+func WithPollTimeout(d time.Duration) Option {
+	return func(c *Client) { c.pollTimeout = d }
+}
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider used to trace
+// RPCs. The default is otel.GetTracerProvider() (a no-op unless a global
+// provider has been configured).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the OpenTelemetry MeterProvider used to record
+// request/poll/task metrics. The default is otel.GetMeterProvider() (a
+// no-op unless a global provider has been configured).
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *Client) { c.meterProvider = mp }
+}
+
+// WithOTelHTTPTransport wraps the Client's *http.Client transport with
+// otelhttp, so every request also produces the spans/metrics otelhttp
+// instruments at the transport level (in addition to the RPC-level spans
+// and metrics this package records directly). It honors WithTracerProvider
+// if set.
+func WithOTelHTTPTransport() Option {
+	return func(c *Client) { c.useOTelHTTP = true }
+}
+
+// NewClient returns a Client targeting baseURL (e.g.
+// "http://localhost:8000/api/v1").
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      baseURL,
+		httpClient:   http.DefaultClient,
+		maxRetries:   defaultMaxRetries,
+		pollInterval: defaultPollInterval,
+		pollTimeout:  defaultPollTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.telemetry = newTelemetry(c.tracerProvider, c.meterProvider)
+
+	if c.useOTelHTTP {
+		wrapped := *c.httpClient
+		wrapped.Transport = otelHTTPTransport(wrapped.Transport, c.tracerProvider)
+		c.httpClient = &wrapped
+	}
+
+	return c
+}
+
+// Submit POSTs to /extract and returns the submission response.
+func (c *Client) Submit(ctx context.Context, req ExtractionRequest) (SubmitResponse, error) {
+	var resp SubmitResponse
+	err := c.doJSON(ctx, http.MethodPost, c.baseURL+"/extract", req, &resp)
+	return resp, err
+}
+
+// SubmitBatch POSTs to /extract/batch and returns the submission response.
+func (c *Client) SubmitBatch(ctx context.Context, req BatchRequest) (BatchSubmitResponse, error) {
+	var resp BatchSubmitResponse
+	err := c.doJSON(ctx, http.MethodPost, c.baseURL+"/extract/batch", req, &resp)
+	return resp, err
+}
+
+// GetTask GETs /tasks/{id} once.
+func (c *Client) GetTask(ctx context.Context, taskID string) (TaskResponse, error) {
+	var resp TaskResponse
+	err := c.doJSON(ctx, http.MethodGet, c.baseURL+"/tasks/"+taskID, nil, &resp)
+	return resp, err
+}
+
+// PollTask polls GetTask until the task reaches a terminal state, the
+// configured poll timeout elapses, or ctx is done.
+func (c *Client) PollTask(ctx context.Context, taskID string) (TaskResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.pollTimeout)
+	defer cancel()
+
+	start := time.Now()
+	for {
+		data, err := c.GetTask(ctx, taskID)
+		c.telemetry.pollIterations.Add(ctx, 1)
+		if err != nil {
+			return data, err
+		}
+		if IsTerminal(data.State) {
+			c.telemetry.taskDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+				attribute.String("state", data.State),
+			))
+			return data, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return TaskResponse{}, fmt.Errorf("poll task %s: %w", taskID, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+// StreamTask returns a channel of TaskEvent for taskID. It long-polls
+// GetTask at the configured poll interval, emitting an event on every state
+// change and a final event once the task reaches a terminal state. The
+// channel is closed after the terminal event, after ctx is cancelled, or
+// after a transport error (delivered as a TaskEvent with Err set).
+//
+// TODO: upgrade to SSE/WebSocket when the server advertises support (e.g. via
+// an `Upgrade` or `Accept`-negotiated response) instead of long-polling.
+func (c *Client) StreamTask(ctx context.Context, taskID string) (<-chan TaskEvent, error) {
+	events := make(chan TaskEvent)
+
+	go func() {
+		defer close(events)
+
+		lastState := ""
+		for {
+			data, err := c.GetTask(ctx, taskID)
+			if err != nil {
+				select {
+				case events <- TaskEvent{TaskID: taskID, Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			if data.State != lastState {
+				lastState = data.State
+				evt := TaskEvent{TaskID: taskID, State: data.State, Result: data.Result, Error: data.Error}
+				select {
+				case events <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if IsTerminal(data.State) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(c.pollInterval):
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// ---------------------------------------------------------------------------
+// Transport
+// ---------------------------------------------------------------------------
+
+// doJSON marshals payload (if non-nil), sends method/url with retries on
+// 429/5xx, and decodes the response body into dst. Each attempt is traced
+// under a single span covering the whole retry sequence and contributes to
+// the requests-total metric, labeled by endpoint and HTTP status class.
+func (c *Client) doJSON(ctx context.Context, method, url string, payload, dst any) (err error) {
+	var body []byte
+	if payload != nil {
+		b, merr := json.Marshal(payload)
+		if merr != nil {
+			return fmt.Errorf("marshal: %w", merr)
+		}
+		body = b
+	}
+
+	endpoint := endpointLabel(c.baseURL, url)
+	var span trace.Span
+	ctx, span = c.telemetry.tracer.Start(ctx, "langextract.request "+endpoint, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.method", method),
+		attribute.String("http.url", url),
+	))
+	statusCode := 0
+	defer func() { endSpan(span, statusCode, err) }()
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		resp, doErr := c.do(ctx, method, url, body)
+		if doErr != nil {
+			lastErr = doErr
+			c.telemetry.recordRequest(ctx, endpoint, 0)
+			if attempt < c.maxRetries {
+				if werr := waitForRetry(ctx, retryDelay(attempt+1, lastErr, nil)); werr != nil {
+					return fmt.Errorf("%s %s: %w", method, url, werr)
+				}
+			}
+			continue
+		}
+		statusCode = resp.StatusCode
+		c.telemetry.recordRequest(ctx, endpoint, resp.StatusCode)
+
+		raw, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			lastErr = fmt.Errorf("read response: %w", readErr)
+			if attempt < c.maxRetries {
+				if werr := waitForRetry(ctx, retryDelay(attempt+1, lastErr, nil)); werr != nil {
+					return fmt.Errorf("%s %s: %w", method, url, werr)
+				}
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, raw)
+			if attempt < c.maxRetries {
+				if werr := waitForRetry(ctx, retryDelay(attempt+1, lastErr, resp)); werr != nil {
+					return fmt.Errorf("%s %s: %w", method, url, werr)
+				}
+			}
+			continue
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, raw)
+		}
+		if dst == nil {
+			return nil
+		}
+		return json.Unmarshal(raw, dst)
+	}
+	return fmt.Errorf("%s %s: giving up after %d attempts: %w", method, url, c.maxRetries+1, lastErr)
+}
+
+// do builds and issues a single HTTP request, applying auth headers and
+// optional gzip request compression.
+func (c *Client) do(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	var reqBody io.Reader
+	contentEncoding := ""
+	if body != nil {
+		if c.gzip {
+			var buf bytes.Buffer
+			gw := gzip.NewWriter(&buf)
+			if _, err := gw.Write(body); err != nil {
+				return nil, fmt.Errorf("gzip request body: %w", err)
+			}
+			if err := gw.Close(); err != nil {
+				return nil, fmt.Errorf("gzip request body: %w", err)
+			}
+			reqBody = &buf
+			contentEncoding = "gzip"
+		} else {
+			reqBody = bytes.NewReader(body)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	if c.gzip {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	c.setAuthHeaders(req)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("gunzip response body: %w", err)
+		}
+		resp.Body = &gzipReadCloser{Reader: gr, underlying: resp.Body}
+	}
+
+	return resp, nil
+}
+
+// setAuthHeaders applies the configured API key / bearer token to req.
+func (c *Client) setAuthHeaders(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+}
+
+// gzipReadCloser adapts a gzip.Reader plus the underlying response body into
+// a single io.ReadCloser that closes both.
+type gzipReadCloser struct {
+	*gzip.Reader
+	underlying io.ReadCloser
+}
+
+func (g *gzipReadCloser) Close() error {
+	gzErr := g.Reader.Close()
+	bodyErr := g.underlying.Close()
+	if gzErr != nil {
+		return gzErr
+	}
+	return bodyErr
+}
+
+// waitForRetry blocks for wait, or returns ctx's error if it's cancelled first.
+func waitForRetry(ctx context.Context, wait time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// retryDelay computes the backoff before the given attempt number (1-based),
+// honoring a Retry-After header on resp when present, else falling back to
+// exponential backoff with full jitter.
+func retryDelay(attempt int, lastErr error, resp *http.Response) time.Duration {
+	if resp != nil {
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+			if when, err := http.ParseTime(ra); err == nil {
+				if d := time.Until(when); d > 0 {
+					return d
+				}
+			}
+		}
+	}
+
+	backoff := defaultRetryBase << uint(attempt-1)
+	if backoff > defaultRetryMax || backoff <= 0 {
+		backoff = defaultRetryMax
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
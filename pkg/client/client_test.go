@@ -0,0 +1,209 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSubmit(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		respBody   SubmitResponse
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			respBody:   SubmitResponse{TaskID: "t-1", Status: "PENDING"},
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/extract" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				_ = json.NewEncoder(w).Encode(tt.respBody)
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, WithMaxRetries(0))
+			got, err := c.Submit(context.Background(), ExtractionRequest{RawText: "hello"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Submit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.TaskID != tt.respBody.TaskID {
+				t.Errorf("TaskID = %q, want %q", got.TaskID, tt.respBody.TaskID)
+			}
+		})
+	}
+}
+
+func TestSubmitRetriesOn5xxThenSucceeds(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithMaxRetries(3))
+	got, err := c.Submit(context.Background(), ExtractionRequest{RawText: "hello"})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if got.TaskID != "t-1" {
+		t.Errorf("TaskID = %q, want t-1", got.TaskID)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestSubmitHonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	var firstAttempt time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if elapsed := time.Since(firstAttempt); elapsed < 900*time.Millisecond || elapsed > 1500*time.Millisecond {
+			t.Errorf("retried after %v, want ~1s (single Retry-After wait)", elapsed)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithMaxRetries(1))
+	if _, err := c.Submit(context.Background(), ExtractionRequest{RawText: "hello"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+}
+
+func TestGetTaskGzipResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		_ = json.NewEncoder(gw).Encode(TaskResponse{TaskID: "t-1", State: StateSuccess})
+		gw.Close()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithGzip(true))
+	got, err := c.GetTask(context.Background(), "t-1")
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if got.State != StateSuccess {
+		t.Errorf("State = %q, want %q", got.State, StateSuccess)
+	}
+}
+
+func TestDoJSONSendsAuthHeaders(t *testing.T) {
+	var gotAPIKey, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAPIKey = r.Header.Get("X-API-Key")
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithAPIKey("secret"), WithBearerToken("tok"))
+	if _, err := c.Submit(context.Background(), ExtractionRequest{}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if gotAPIKey != "secret" {
+		t.Errorf("X-API-Key = %q, want secret", gotAPIKey)
+	}
+	if gotAuth != "Bearer tok" {
+		t.Errorf("Authorization = %q, want Bearer tok", gotAuth)
+	}
+}
+
+func TestPollTaskReachesTerminalState(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		state := "PENDING"
+		if calls >= 2 {
+			state = StateSuccess
+		}
+		_ = json.NewEncoder(w).Encode(TaskResponse{TaskID: "t-1", State: state})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithPollInterval(time.Millisecond), WithPollTimeout(time.Second))
+	got, err := c.PollTask(context.Background(), "t-1")
+	if err != nil {
+		t.Fatalf("PollTask() error = %v", err)
+	}
+	if got.State != StateSuccess {
+		t.Errorf("State = %q, want %q", got.State, StateSuccess)
+	}
+}
+
+func TestStreamTaskEmitsStateTransitions(t *testing.T) {
+	states := []string{"PENDING", "STARTED", StateSuccess}
+	idx := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := states[idx]
+		if idx < len(states)-1 {
+			idx++
+		}
+		_ = json.NewEncoder(w).Encode(TaskResponse{TaskID: "t-1", State: state})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithPollInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	events, err := c.StreamTask(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("StreamTask() error = %v", err)
+	}
+
+	var got []string
+	for evt := range events {
+		if evt.Err != nil {
+			t.Fatalf("unexpected stream error: %v", evt.Err)
+		}
+		got = append(got, evt.State)
+	}
+
+	if len(got) != len(states) {
+		t.Fatalf("got %d events %v, want %d events %v", len(got), got, len(states), states)
+	}
+	for i, want := range states {
+		if got[i] != want {
+			t.Errorf("event %d = %q, want %q", i, got[i], want)
+		}
+	}
+}
+
+var _ io.Closer = (*gzipReadCloser)(nil)
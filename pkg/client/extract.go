@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Extract submits req for extraction and unmarshals the result into a new
+// T instead of the loose []Entity slice. T must be a struct whose exported
+// fields carry a `langextract` tag describing which entity class each field
+// maps to, e.g.:
+//
+//	type Party struct {
+//		Buyer string            `langextract:"class=buyer,required"`
+//		Terms map[string]string `langextract:"class=party,attrs=role;jurisdiction"`
+//	}
+//
+// Tag elements:
+//
+//   - class=a;b: the extraction_class value(s) this field accepts. Required.
+//   - attrs=a;b: for map[string]string fields, the attribute keys to copy
+//     from the matching entity's Attributes.
+//   - required: fail the call if no entity matches this field's class(es).
+//
+// string and []string fields are populated from the matching entit(y/ies)'
+// ExtractionText; map[string]string fields are populated from Attributes.
+// The union of every field's class is the complete vocabulary of valid
+// extraction_class values for T — an entity returned with any other class
+// fails the call, so there is no separate enum tag to write.
+//
+// Extract is a package-level function rather than a Client method because
+// Go does not allow type parameters on methods.
+func Extract[T any](ctx context.Context, c *Client, req ExtractionRequest) (T, error) {
+	var out T
+	specs, err := parseFieldSpecs(reflect.TypeOf(out))
+	if err != nil {
+		return out, err
+	}
+
+	req.ExtractionConfig.PromptDescription = buildPromptDescription(specs)
+	req.ResponseSchema = buildResponseSchema(specs)
+
+	submit, err := c.Submit(ctx, req)
+	if err != nil {
+		return out, err
+	}
+	final, err := c.PollTask(ctx, submit.TaskID)
+	if err != nil {
+		return out, err
+	}
+	if final.State != StateSuccess || final.Result == nil {
+		return out, fmt.Errorf("extract: task %s did not succeed: state=%s error=%s", submit.TaskID, final.State, final.Error)
+	}
+
+	if err := populate(&out, specs, final.Result.Entities); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// fieldKind distinguishes how a tagged field is populated from entities.
+type fieldKind int
+
+const (
+	fieldText  fieldKind = iota // string or []string, from ExtractionText
+	fieldAttrs                  // map[string]string, from Attributes
+)
+
+// fieldSpec is a parsed `langextract` struct tag for one field of a type
+// passed to Extract.
+type fieldSpec struct {
+	name     string
+	index    int
+	classes  []string
+	attrs    []string
+	required bool
+	kind     fieldKind
+}
+
+// parseFieldSpecs reflects over t (which must be a struct type) and parses
+// each exported field's `langextract` tag. Fields without the tag are
+// ignored.
+func parseFieldSpecs(t reflect.Type) ([]fieldSpec, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("langextract: %s is not a struct", t)
+	}
+
+	var specs []fieldSpec
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, ok := f.Tag.Lookup("langextract")
+		if !ok {
+			continue
+		}
+
+		spec := fieldSpec{name: f.Name, index: i}
+		for _, part := range strings.Split(tag, ",") {
+			part = strings.TrimSpace(part)
+			switch {
+			case part == "":
+			case part == "required":
+				spec.required = true
+			case strings.HasPrefix(part, "class="):
+				spec.classes = append(spec.classes, strings.Split(strings.TrimPrefix(part, "class="), ";")...)
+			case strings.HasPrefix(part, "attrs="):
+				spec.attrs = append(spec.attrs, strings.Split(strings.TrimPrefix(part, "attrs="), ";")...)
+			default:
+				return nil, fmt.Errorf("langextract: field %s: unrecognised tag element %q", f.Name, part)
+			}
+		}
+		if len(spec.classes) == 0 {
+			return nil, fmt.Errorf("langextract: field %s: tag must set class=", f.Name)
+		}
+
+		switch {
+		case f.Type.Kind() == reflect.String:
+			spec.kind = fieldText
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String:
+			spec.kind = fieldText
+		case f.Type.Kind() == reflect.Map && f.Type.Key().Kind() == reflect.String && f.Type.Elem().Kind() == reflect.String:
+			spec.kind = fieldAttrs
+		default:
+			return nil, fmt.Errorf("langextract: field %s: unsupported type %s (want string, []string, or map[string]string)", f.Name, f.Type)
+		}
+
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("langextract: %s has no langextract-tagged fields", t)
+	}
+	return specs, nil
+}
+
+// buildPromptDescription renders a human-readable extraction instruction
+// from specs, sent as ExtractionConfig.PromptDescription.
+func buildPromptDescription(specs []fieldSpec) string {
+	var b strings.Builder
+	b.WriteString("Extract the following entity classes:\n")
+	for _, s := range specs {
+		fmt.Fprintf(&b, "- %s", strings.Join(s.classes, " or "))
+		if s.required {
+			b.WriteString(" (required)")
+		}
+		if len(s.attrs) > 0 {
+			fmt.Fprintf(&b, "; attributes: %s", strings.Join(s.attrs, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// buildResponseSchema renders a JSON-schema-style document describing the
+// entities LangExtract should return, sent as ExtractionRequest.ResponseSchema.
+func buildResponseSchema(specs []fieldSpec) map[string]any {
+	classes := make(map[string]any, len(specs))
+	for _, s := range specs {
+		props := map[string]any{
+			"extraction_text": map[string]any{"type": "string"},
+		}
+		if len(s.attrs) > 0 {
+			attrProps := make(map[string]any, len(s.attrs))
+			for _, a := range s.attrs {
+				attrProps[a] = map[string]any{"type": "string"}
+			}
+			props["attributes"] = map[string]any{"type": "object", "properties": attrProps}
+		}
+		for _, class := range s.classes {
+			classes[class] = map[string]any{"type": "object", "properties": props, "required": []string{"extraction_text"}}
+		}
+	}
+	return map[string]any{"type": "array", "classes": classes}
+}
+
+// populate fills dst's tagged fields from entities, enforcing that every
+// entity's class is one this schema declared and that required fields
+// matched at least one entity.
+func populate(dst any, specs []fieldSpec, entities []Entity) error {
+	allowed := make(map[string]bool)
+	for _, s := range specs {
+		for _, class := range s.classes {
+			allowed[class] = true
+		}
+	}
+	for _, e := range entities {
+		if !allowed[e.ExtractionClass] {
+			return fmt.Errorf("extract: entity has unexpected extraction_class %q", e.ExtractionClass)
+		}
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	for _, s := range specs {
+		matches := matchingEntities(s.classes, entities)
+		if s.required && len(matches) == 0 {
+			return fmt.Errorf("extract: field %s: no entity found for class(es) %s", s.name, strings.Join(s.classes, ", "))
+		}
+
+		field := v.Field(s.index)
+		switch s.kind {
+		case fieldText:
+			setText(field, matches)
+		case fieldAttrs:
+			setAttrs(field, s.attrs, matches)
+		}
+	}
+	return nil
+}
+
+func matchingEntities(classes []string, entities []Entity) []Entity {
+	want := make(map[string]bool, len(classes))
+	for _, c := range classes {
+		want[c] = true
+	}
+	var matches []Entity
+	for _, e := range entities {
+		if want[e.ExtractionClass] {
+			matches = append(matches, e)
+		}
+	}
+	return matches
+}
+
+func setText(field reflect.Value, matches []Entity) {
+	if len(matches) == 0 {
+		return
+	}
+	if field.Kind() == reflect.Slice {
+		texts := make([]string, len(matches))
+		for i, m := range matches {
+			texts[i] = m.ExtractionText
+		}
+		field.Set(reflect.ValueOf(texts))
+		return
+	}
+	field.SetString(matches[0].ExtractionText)
+}
+
+func setAttrs(field reflect.Value, attrs []string, matches []Entity) {
+	if len(matches) == 0 {
+		return
+	}
+	want := make(map[string]bool, len(attrs))
+	for _, a := range attrs {
+		want[a] = true
+	}
+	out := make(map[string]string)
+	for k, v := range matches[0].Attributes {
+		if len(attrs) == 0 || want[k] {
+			out[k] = v
+		}
+	}
+	field.Set(reflect.ValueOf(out))
+}
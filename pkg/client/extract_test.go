@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type party struct {
+	Buyer  string            `langextract:"class=buyer,required"`
+	Seller string            `langextract:"class=seller"`
+	Terms  map[string]string `langextract:"class=party,attrs=role;jurisdiction"`
+}
+
+func TestExtractPopulatesTaggedFields(t *testing.T) {
+	var gotReq ExtractionRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/extract":
+			_ = json.NewDecoder(r.Body).Decode(&gotReq)
+			_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+		case "/tasks/t-1":
+			_ = json.NewEncoder(w).Encode(TaskResponse{
+				TaskID: "t-1",
+				State:  StateSuccess,
+				Result: &TaskResult{
+					Entities: []Entity{
+						{ExtractionClass: "buyer", ExtractionText: "Acme Corp"},
+						{ExtractionClass: "seller", ExtractionText: "Beta LLC"},
+						{ExtractionClass: "party", ExtractionText: "Acme Corp", Attributes: map[string]string{"role": "buyer", "jurisdiction": "Delaware"}},
+					},
+				},
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithPollInterval(0))
+	got, err := Extract[party](context.Background(), c, ExtractionRequest{RawText: "irrelevant"})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if got.Buyer != "Acme Corp" {
+		t.Errorf("Buyer = %q, want %q", got.Buyer, "Acme Corp")
+	}
+	if got.Seller != "Beta LLC" {
+		t.Errorf("Seller = %q, want %q", got.Seller, "Beta LLC")
+	}
+	if got.Terms["role"] != "buyer" {
+		t.Errorf("Terms[role] = %q, want %q", got.Terms["role"], "buyer")
+	}
+	if _, ok := got.Terms["jurisdiction"]; !ok {
+		t.Errorf("Terms missing jurisdiction attribute: %+v", got.Terms)
+	}
+
+	if gotReq.ExtractionConfig.PromptDescription == "" {
+		t.Error("PromptDescription was not auto-generated")
+	}
+	if gotReq.ResponseSchema == nil {
+		t.Error("ResponseSchema was not auto-generated")
+	}
+}
+
+func TestExtractRequiredFieldMissingFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/extract":
+			_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+		case "/tasks/t-1":
+			_ = json.NewEncoder(w).Encode(TaskResponse{
+				TaskID: "t-1",
+				State:  StateSuccess,
+				Result: &TaskResult{Entities: []Entity{
+					{ExtractionClass: "seller", ExtractionText: "Beta LLC"},
+				}},
+			})
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithPollInterval(0))
+	if _, err := Extract[party](context.Background(), c, ExtractionRequest{RawText: "irrelevant"}); err == nil {
+		t.Fatal("Extract() error = nil, want error for missing required Buyer")
+	}
+}
+
+func TestExtractUnexpectedClassFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/extract":
+			_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+		case "/tasks/t-1":
+			_ = json.NewEncoder(w).Encode(TaskResponse{
+				TaskID: "t-1",
+				State:  StateSuccess,
+				Result: &TaskResult{Entities: []Entity{
+					{ExtractionClass: "date", ExtractionText: "2025-01-15"},
+				}},
+			})
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithPollInterval(0))
+	if _, err := Extract[party](context.Background(), c, ExtractionRequest{RawText: "irrelevant"}); err == nil {
+		t.Fatal("Extract() error = nil, want error for out-of-vocabulary extraction_class")
+	}
+}
+
+func TestParseFieldSpecsRejectsUnsupportedType(t *testing.T) {
+	type bad struct {
+		Count int `langextract:"class=count"`
+	}
+	if _, err := parseFieldSpecs(reflect.TypeOf(bad{})); err == nil {
+		t.Fatal("parseFieldSpecs() error = nil, want error for unsupported field type")
+	}
+}
+
+func TestParseFieldSpecsRequiresClass(t *testing.T) {
+	type bad struct {
+		Name string `langextract:"attrs=role"`
+	}
+	if _, err := parseFieldSpecs(reflect.TypeOf(bad{})); err == nil {
+		t.Fatal("parseFieldSpecs() error = nil, want error for missing class")
+	}
+}
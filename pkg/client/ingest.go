@@ -0,0 +1,239 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+const (
+	// defaultInlineThreshold is the largest file SubmitFile will inline as
+	// ExtractionRequest.RawText rather than multipart-uploading.
+	defaultInlineThreshold = 64 << 10 // 64KiB
+
+	// defaultIngestWorkers bounds how many files SubmitDir submits
+	// concurrently.
+	defaultIngestWorkers = 8
+)
+
+// FileProgress reports the outcome of submitting a single file discovered
+// by SubmitDir.
+type FileProgress struct {
+	Path   string
+	Submit SubmitResponse
+	Err    error
+}
+
+// IngestOption configures SubmitDir.
+type IngestOption func(*ingestConfig)
+
+type ingestConfig struct {
+	workers int
+}
+
+// WithWorkers bounds how many files SubmitDir submits concurrently. The
+// default is 8.
+func WithWorkers(n int) IngestOption {
+	return func(c *ingestConfig) { c.workers = n }
+}
+
+// SubmitFile reads path and submits it for extraction. req supplies the
+// provider/extraction-config template; its RawText is replaced by the file's
+// content.
+//
+// If IdempotencyKey is unset, it is derived as
+// sha256(content)+sha256(extraction_config) so resubmitting the same file
+// with the same config dedupes server-side; a key already set on req is
+// sent unchanged. Files no larger than 64KiB are inlined as raw_text via
+// Submit; larger files are read into memory and uploaded as a multipart
+// form to POST /extract/upload.
+func (c *Client) SubmitFile(ctx context.Context, path string, req ExtractionRequest) (SubmitResponse, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SubmitResponse{}, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if req.IdempotencyKey == "" {
+		key, err := contentIdempotencyKey(content, req.ExtractionConfig)
+		if err != nil {
+			return SubmitResponse{}, fmt.Errorf("derive idempotency key for %s: %w", path, err)
+		}
+		req.IdempotencyKey = key
+	}
+
+	if len(content) <= defaultInlineThreshold {
+		req.RawText = string(content)
+		return c.Submit(ctx, req)
+	}
+	return c.submitUpload(ctx, path, content, req)
+}
+
+// contentIdempotencyKey derives an idempotency key from file content and the
+// extraction config applied to it, so the same corpus submitted twice under
+// the same config dedupes server-side.
+func contentIdempotencyKey(content []byte, cfg ExtractionConfig) (string, error) {
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("marshal extraction config: %w", err)
+	}
+	contentSum := sha256.Sum256(content)
+	cfgSum := sha256.Sum256(cfgJSON)
+	return hex.EncodeToString(contentSum[:]) + hex.EncodeToString(cfgSum[:]), nil
+}
+
+// submitUpload POSTs content as a multipart file upload to /extract/upload,
+// for files too large to inline as raw_text.
+func (c *Client) submitUpload(ctx context.Context, path string, content []byte, req ExtractionRequest) (SubmitResponse, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	fw, err := mw.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return SubmitResponse{}, fmt.Errorf("build upload for %s: %w", path, err)
+	}
+	if _, err := fw.Write(content); err != nil {
+		return SubmitResponse{}, fmt.Errorf("build upload for %s: %w", path, err)
+	}
+	if req.Provider != "" {
+		_ = mw.WriteField("provider", req.Provider)
+	}
+	if req.Passes != 0 {
+		_ = mw.WriteField("passes", strconv.Itoa(req.Passes))
+	}
+	_ = mw.WriteField("idempotency_key", req.IdempotencyKey)
+	cfgJSON, err := json.Marshal(req.ExtractionConfig)
+	if err != nil {
+		return SubmitResponse{}, fmt.Errorf("marshal extraction config for %s: %w", path, err)
+	}
+	_ = mw.WriteField("extraction_config", string(cfgJSON))
+	if err := mw.Close(); err != nil {
+		return SubmitResponse{}, fmt.Errorf("build upload for %s: %w", path, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/extract/upload", &body)
+	if err != nil {
+		return SubmitResponse{}, fmt.Errorf("upload %s: %w", path, err)
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	c.setAuthHeaders(httpReq)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return SubmitResponse{}, fmt.Errorf("upload %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return SubmitResponse{}, fmt.Errorf("read upload response for %s: %w", path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return SubmitResponse{}, fmt.Errorf("upload %s: HTTP %d: %s", path, resp.StatusCode, raw)
+	}
+
+	var out SubmitResponse
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return SubmitResponse{}, fmt.Errorf("decode upload response for %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// SubmitDir walks root, submitting every file matching glob (matched
+// against the file's base name, e.g. "*.txt"; an empty glob matches every
+// file) for extraction using req as a template. Directories and files
+// matched by a .gitignore at the root of the walk are skipped. Submissions
+// run through a bounded worker pool (see WithWorkers) so a large corpus
+// applies backpressure on the walk instead of spawning unbounded
+// goroutines; progress is reported on the returned channel as each file
+// completes, and the channel is closed once the walk and all submissions
+// finish.
+func (c *Client) SubmitDir(ctx context.Context, root, glob string, req ExtractionRequest, opts ...IngestOption) (<-chan FileProgress, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("stat %s: %w", root, err)
+	}
+
+	cfg := ingestConfig{workers: defaultIngestWorkers}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var excludes *ignore.GitIgnore
+	if gi, err := ignore.CompileIgnoreFile(filepath.Join(root, ".gitignore")); err == nil {
+		excludes = gi
+	}
+
+	progress := make(chan FileProgress)
+
+	go func() {
+		defer close(progress)
+
+		sem := make(chan struct{}, cfg.workers)
+		var wg sync.WaitGroup
+
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				select {
+				case progress <- FileProgress{Path: path, Err: err}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			rel, relErr := filepath.Rel(root, path)
+			if relErr == nil && excludes != nil && excludes.MatchesPath(rel) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if glob != "" {
+				if matched, _ := filepath.Match(glob, d.Name()); !matched {
+					return nil
+				}
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			wg.Add(1)
+			go func(path string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				resp, err := c.SubmitFile(ctx, path, req)
+				select {
+				case progress <- FileProgress{Path: path, Submit: resp, Err: err}:
+				case <-ctx.Done():
+				}
+			}(path)
+			return nil
+		})
+
+		wg.Wait()
+	}()
+
+	return progress, nil
+}
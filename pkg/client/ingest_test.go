@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSubmitFileInlinesSmallFile(t *testing.T) {
+	var gotReq ExtractionRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/extract" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.txt")
+	if err := os.WriteFile(path, []byte("hello world"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(srv.URL)
+	resp, err := c.SubmitFile(context.Background(), path, ExtractionRequest{Provider: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("SubmitFile() error = %v", err)
+	}
+	if resp.TaskID != "t-1" {
+		t.Errorf("TaskID = %q, want t-1", resp.TaskID)
+	}
+	if gotReq.RawText != "hello world" {
+		t.Errorf("RawText = %q, want %q", gotReq.RawText, "hello world")
+	}
+	if gotReq.IdempotencyKey == "" {
+		t.Error("IdempotencyKey was not derived")
+	}
+}
+
+func TestSubmitFileDeterministicIdempotencyKey(t *testing.T) {
+	var gotKeys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ExtractionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		gotKeys = append(gotKeys, req.IdempotencyKey)
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.txt")
+	pathB := filepath.Join(dir, "b.txt")
+	if err := os.WriteFile(pathA, []byte("same content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pathB, []byte("same content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(srv.URL)
+	_, errA := c.SubmitFile(context.Background(), pathA, ExtractionRequest{})
+	_, errB := c.SubmitFile(context.Background(), pathB, ExtractionRequest{})
+	if errA != nil || errB != nil {
+		t.Fatalf("SubmitFile errors: %v, %v", errA, errB)
+	}
+	if len(gotKeys) != 2 {
+		t.Fatalf("server saw %d submissions, want 2", len(gotKeys))
+	}
+	keyA, keyB := gotKeys[0], gotKeys[1]
+	if keyA != keyB {
+		t.Errorf("keys differ for identical content: %q vs %q", keyA, keyB)
+	}
+
+	keyDifferentConfig, err := contentIdempotencyKey([]byte("same content"), ExtractionConfig{Temperature: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyDifferentConfig == keyA {
+		t.Error("key should change when extraction config changes")
+	}
+}
+
+func TestSubmitFileUploadsLargeFile(t *testing.T) {
+	var gotContentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/extract/upload" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		gotContentType = r.Header.Get("Content-Type")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer file.Close()
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-big"})
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "big.txt")
+	big := strings.Repeat("x", defaultInlineThreshold+1)
+	if err := os.WriteFile(path, []byte(big), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(srv.URL)
+	resp, err := c.SubmitFile(context.Background(), path, ExtractionRequest{})
+	if err != nil {
+		t.Fatalf("SubmitFile() error = %v", err)
+	}
+	if resp.TaskID != "t-big" {
+		t.Errorf("TaskID = %q, want t-big", resp.TaskID)
+	}
+	if !strings.HasPrefix(gotContentType, "multipart/form-data") {
+		t.Errorf("Content-Type = %q, want multipart/form-data prefix", gotContentType)
+	}
+}
+
+func TestSubmitDirRespectsGlobAndGitignore(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite := func(rel, content string) {
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustWrite("keep.txt", "keep me")
+	mustWrite("skip.txt", "skip via gitignore")
+	mustWrite("notes.md", "wrong extension")
+	mustWrite("vendor/keep.txt", "excluded dir")
+	mustWrite(".gitignore", "skip.txt\nvendor/\n")
+
+	var mu sync.Mutex
+	var submitted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ExtractionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		submitted = append(submitted, req.RawText)
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	progress, err := c.SubmitDir(context.Background(), dir, "*.txt", ExtractionRequest{}, WithWorkers(2))
+	if err != nil {
+		t.Fatalf("SubmitDir() error = %v", err)
+	}
+
+	var results []FileProgress
+	for p := range progress {
+		results = append(results, p)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1 (keep.txt only): %+v", len(results), results)
+	}
+	if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(submitted) != 1 || submitted[0] != "keep me" {
+		t.Errorf("submitted = %v, want [\"keep me\"]", submitted)
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package to tracer/meter providers.
+const instrumentationName = "github.com/IgnatG/langextract-api/pkg/client"
+
+// telemetry holds the tracer and metric instruments shared by a Client's
+// RPCs. It is always populated (falling back to OpenTelemetry's global
+// no-op providers) so call sites never need a nil check.
+type telemetry struct {
+	tracer trace.Tracer
+
+	requestsTotal  metric.Int64Counter
+	pollIterations metric.Int64Counter
+	taskDuration   metric.Float64Histogram
+}
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) *telemetry {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+
+	requestsTotal, _ := meter.Int64Counter(
+		"langextract_client_requests_total",
+		metric.WithDescription("HTTP requests made by the LangExtract client, by endpoint and status class"),
+	)
+	pollIterations, _ := meter.Int64Counter(
+		"langextract_client_poll_iterations_total",
+		metric.WithDescription("PollTask GetTask iterations performed"),
+	)
+	taskDuration, _ := meter.Float64Histogram(
+		"langextract_client_task_duration_seconds",
+		metric.WithDescription("Time from PollTask start to a task reaching a terminal state"),
+		metric.WithUnit("s"),
+	)
+
+	return &telemetry{
+		tracer:         tp.Tracer(instrumentationName),
+		requestsTotal:  requestsTotal,
+		pollIterations: pollIterations,
+		taskDuration:   taskDuration,
+	}
+}
+
+// endSpan records the outcome of an RPC span: the HTTP status (if any) and,
+// on error, the error itself.
+func endSpan(span trace.Span, statusCode int, err error) {
+	if statusCode > 0 {
+		span.SetAttributes(attribute.Int("http.status_code", statusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// recordRequest increments the requests-total counter, labeled by endpoint
+// and HTTP status class (e.g. "2xx", "5xx").
+func (t *telemetry) recordRequest(ctx context.Context, endpoint string, statusCode int) {
+	t.requestsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("endpoint", endpoint),
+		attribute.String("status_class", statusClass(statusCode)),
+	))
+}
+
+func statusClass(statusCode int) string {
+	if statusCode <= 0 {
+		return "error"
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// endpointLabel reduces a request URL to a low-cardinality metric label,
+// e.g. "http://host/api/v1/extract/batch" -> "/extract/batch". Path segments
+// that embed an identifier (/tasks/{task_id}) are collapsed so the label
+// doesn't grow unbounded with task count.
+func endpointLabel(baseURL, url string) string {
+	rest := url
+	if len(url) >= len(baseURL) && url[:len(baseURL)] == baseURL {
+		rest = url[len(baseURL):]
+	}
+	if rest == "" {
+		return "/"
+	}
+	const tasksPrefix = "/tasks/"
+	if len(rest) > len(tasksPrefix) && rest[:len(tasksPrefix)] == tasksPrefix {
+		return tasksPrefix + "{task_id}"
+	}
+	return rest
+}
+
+// NewPrometheusMeterProvider returns a metric.MeterProvider that registers
+// this package's instruments (and any others recorded against it) with reg,
+// exposing them in Prometheus exposition format. Pass the result to
+// WithMeterProvider and serve reg (or promhttp.HandlerFor(reg, ...)) at your
+// usual /metrics endpoint.
+func NewPrometheusMeterProvider(reg *prometheus.Registry) (metric.MeterProvider, error) {
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(reg))
+	if err != nil {
+		return nil, err
+	}
+	return sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter)), nil
+}
+
+// otelHTTPTransport wraps base (or http.DefaultTransport if nil) with
+// otelhttp's span/metric-producing RoundTripper.
+func otelHTTPTransport(base http.RoundTripper, tp trace.TracerProvider) http.RoundTripper {
+	opts := []otelhttp.Option{}
+	if tp != nil {
+		opts = append(opts, otelhttp.WithTracerProvider(tp))
+	}
+	return otelhttp.NewTransport(base, opts...)
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDoJSONRecordsSpanAndMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(SubmitResponse{TaskID: "t-1", Status: "PENDING"})
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	c := NewClient(srv.URL, WithMaxRetries(0), WithTracerProvider(tp), WithMeterProvider(mp))
+	if _, err := c.Submit(context.Background(), ExtractionRequest{RawText: "hello"}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "langextract.request /extract" {
+		t.Errorf("span name = %q, want %q", span.Name, "langextract.request /extract")
+	}
+	wantAttrs := map[string]string{"http.method": "POST"}
+	for _, kv := range span.Attributes {
+		if want, ok := wantAttrs[string(kv.Key)]; ok && kv.Value.AsString() != want {
+			t.Errorf("attribute %s = %q, want %q", kv.Key, kv.Value.AsString(), want)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	counter := findMetric(t, rm, "langextract_client_requests_total")
+	sum, ok := counter.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("requests_total is %T, want Sum[int64]", counter.Data)
+	}
+	if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+		t.Fatalf("requests_total data points = %+v, want one point with value 1", sum.DataPoints)
+	}
+}
+
+func TestDoJSONRecordsErrorOnFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := NewClient(srv.URL, WithMaxRetries(0), WithTracerProvider(tp))
+	if _, err := c.Submit(context.Background(), ExtractionRequest{RawText: "hello"}); err == nil {
+		t.Fatal("Submit() error = nil, want error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if spans[0].Status.Code.String() != "Error" {
+		t.Errorf("span status = %v, want Error", spans[0].Status.Code)
+	}
+	if len(spans[0].Events) == 0 {
+		t.Error("expected an exception event recording the error, got none")
+	}
+}
+
+func TestPollTaskRecordsIterationsAndDuration(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		state := "RUNNING"
+		if calls >= 2 {
+			state = StateSuccess
+		}
+		_ = json.NewEncoder(w).Encode(TaskResponse{TaskID: "t-1", State: state})
+	}))
+	defer srv.Close()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	c := NewClient(srv.URL, WithMeterProvider(mp), WithPollInterval(0))
+
+	if _, err := c.PollTask(context.Background(), "t-1"); err != nil {
+		t.Fatalf("PollTask() error = %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	iterations := findMetric(t, rm, "langextract_client_poll_iterations_total")
+	sum, ok := iterations.Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != int64(calls) {
+		t.Fatalf("poll_iterations_total = %+v, want a single point with value %d", iterations.Data, calls)
+	}
+
+	duration := findMetric(t, rm, "langextract_client_task_duration_seconds")
+	hist, ok := duration.Data.(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Count != 1 {
+		t.Fatalf("task_duration_seconds = %+v, want one recorded observation", duration.Data)
+	}
+}
+
+func findMetric(t *testing.T, rm metricdata.ResourceMetrics, name string) metricdata.Metrics {
+	t.Helper()
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	t.Fatalf("metric %q not found", name)
+	return metricdata.Metrics{}
+}
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       string
+	}{
+		{0, "error"},
+		{200, "2xx"},
+		{404, "4xx"},
+		{503, "5xx"},
+	}
+	for _, tt := range tests {
+		if got := statusClass(tt.statusCode); got != tt.want {
+			t.Errorf("statusClass(%d) = %q, want %q", tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+func TestEndpointLabel(t *testing.T) {
+	const base = "http://localhost:8000/api/v1"
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{base + "/extract", "/extract"},
+		{base + "/tasks/abc-123", "/tasks/{task_id}"},
+		{base, "/"},
+	}
+	for _, tt := range tests {
+		if got := endpointLabel(base, tt.url); got != tt.want {
+			t.Errorf("endpointLabel(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+// ExtractionConfig holds optional LangExtract pipeline overrides.
+type ExtractionConfig struct {
+	PromptDescription string  `json:"prompt_description,omitempty"`
+	Temperature       float64 `json:"temperature,omitempty"`
+}
+
+// ExtractionRequest is the body for POST /extract.
+type ExtractionRequest struct {
+	RawText          string           `json:"raw_text,omitempty"`
+	DocumentURL      string           `json:"document_url,omitempty"`
+	Provider         string           `json:"provider,omitempty"`
+	Passes           int              `json:"passes,omitempty"`
+	IdempotencyKey   string           `json:"idempotency_key,omitempty"`
+	ExtractionConfig ExtractionConfig `json:"extraction_config,omitempty"`
+
+	// ResponseSchema constrains the shape of the entities LangExtract
+	// returns. Extract populates it automatically from a Go struct's
+	// `langextract` tags; callers submitting requests directly may also set
+	// it by hand.
+	ResponseSchema any `json:"response_schema,omitempty"`
+}
+
+// SubmitResponse is the body returned by POST /extract.
+type SubmitResponse struct {
+	TaskID  string `json:"task_id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// BatchRequest is the body for POST /extract/batch.
+type BatchRequest struct {
+	BatchID   string              `json:"batch_id"`
+	Documents []ExtractionRequest `json:"documents"`
+	Provider  string              `json:"provider,omitempty"`
+}
+
+// BatchSubmitResponse is the body returned by POST /extract/batch.
+type BatchSubmitResponse struct {
+	BatchID     string   `json:"batch_id"`
+	TaskIDs     []string `json:"task_ids"`
+	BatchTaskID string   `json:"batch_task_id"`
+}
+
+// Entity is a single extracted entity in a completed task result.
+type Entity struct {
+	ExtractionClass string            `json:"extraction_class"`
+	ExtractionText  string            `json:"extraction_text"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+}
+
+// TaskResult holds the list of extracted entities.
+type TaskResult struct {
+	Entities []Entity `json:"entities"`
+}
+
+// TaskResponse is the body returned by GET /tasks/{id}.
+type TaskResponse struct {
+	TaskID string      `json:"task_id"`
+	State  string      `json:"state"`
+	Result *TaskResult `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Terminal task states reported in TaskResponse.State.
+const (
+	StateSuccess = "SUCCESS"
+	StateFailure = "FAILURE"
+)
+
+// IsTerminal reports whether state is a final task state.
+func IsTerminal(state string) bool {
+	return state == StateSuccess || state == StateFailure
+}
+
+// TaskEvent is a single state transition delivered by StreamTask.
+type TaskEvent struct {
+	TaskID string
+	State  string
+	Result *TaskResult
+	Error  string
+
+	// Err is set when polling the task failed (e.g. a transport error)
+	// rather than the task reporting a failure. The channel is closed after
+	// an Err event; cancelling ctx closes the channel without necessarily
+	// delivering one.
+	Err error
+}
@@ -0,0 +1,91 @@
+package sink
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/IgnatG/langextract-api/pkg/client"
+)
+
+// CSVSink writes one row per extracted entity as CSV, with Attributes
+// flattened into columns. The column set is inferred from the first
+// SchemaPrefix rows (default defaultSchemaPrefix); an attribute key first
+// seen after that point is dropped rather than reshaping an already-written
+// header.
+type CSVSink struct {
+	w      *csv.Writer
+	schema *schemaBuffer
+}
+
+// CSVOption configures a CSVSink.
+type CSVOption func(*CSVSink)
+
+// WithSchemaPrefix overrides how many rows are scanned to infer the
+// attribute column set before the header is written.
+func WithSchemaPrefix(n int) CSVOption {
+	return func(s *CSVSink) { s.schema = newSchemaBuffer(n) }
+}
+
+// NewCSVSink returns a CSVSink writing to w.
+func NewCSVSink(w io.Writer, opts ...CSVOption) *CSVSink {
+	s := &CSVSink{w: csv.NewWriter(w), schema: newSchemaBuffer(defaultSchemaPrefix)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Write implements Writer.
+func (s *CSVSink) Write(resp client.TaskResponse) error {
+	for _, row := range flatten(resp) {
+		if s.schema.finalized() {
+			if err := s.writeRow(row); err != nil {
+				return err
+			}
+			continue
+		}
+		if ready := s.schema.add(row); ready {
+			if err := s.flushSchema(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flushSchema finalizes the column order, writes the header, and flushes
+// any buffered rows.
+func (s *CSVSink) flushSchema() error {
+	columns, buffered := s.schema.finalize()
+	if err := s.w.Write(columns); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+	for _, row := range buffered {
+		if err := s.writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CSVSink) writeRow(row flatEntity) error {
+	vals, _ := valuesForColumns(s.schema.columns, row)
+	if err := s.w.Write(vals); err != nil {
+		return fmt.Errorf("write csv row: %w", err)
+	}
+	return nil
+}
+
+// Close implements Writer. It finalizes the schema from whatever rows were
+// buffered (if the prefix limit was never reached) and flushes the
+// underlying csv.Writer.
+func (s *CSVSink) Close() error {
+	if !s.schema.finalized() {
+		if err := s.flushSchema(); err != nil {
+			return err
+		}
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
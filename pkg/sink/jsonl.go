@@ -0,0 +1,50 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/IgnatG/langextract-api/pkg/client"
+)
+
+// jsonlRow is the shape written per entity by JSONLSink. Unlike the
+// columnar sinks, Attributes is left as a nested object rather than
+// flattened, since JSON has no fixed-schema constraint to satisfy.
+type jsonlRow struct {
+	TaskID          string            `json:"task_id"`
+	ExtractionClass string            `json:"extraction_class"`
+	ExtractionText  string            `json:"extraction_text"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+}
+
+// JSONLSink writes one JSON object per extracted entity, newline-delimited.
+type JSONLSink struct {
+	enc *json.Encoder
+}
+
+// NewJSONLSink returns a JSONLSink writing to w.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{enc: json.NewEncoder(w)}
+}
+
+// Write implements Writer.
+func (s *JSONLSink) Write(resp client.TaskResponse) error {
+	for _, row := range flatten(resp) {
+		if err := s.enc.Encode(jsonlRow{
+			TaskID:          row.TaskID,
+			ExtractionClass: row.ExtractionClass,
+			ExtractionText:  row.ExtractionText,
+			Attributes:      row.Attributes,
+		}); err != nil {
+			return fmt.Errorf("write jsonl row: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close implements Writer. JSONLSink holds no resources of its own to
+// release; the caller owns the underlying io.Writer.
+func (s *JSONLSink) Close() error {
+	return nil
+}
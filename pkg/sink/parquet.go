@@ -0,0 +1,113 @@
+package sink
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/IgnatG/langextract-api/pkg/client"
+	"github.com/parquet-go/parquet-go"
+)
+
+// ParquetSink writes one row per extracted entity to a Parquet file, with
+// Attributes flattened into columns. As with CSVSink, the column set is
+// inferred from a bounded prefix of rows before the Parquet schema (and
+// therefore the file's column layout) is fixed.
+type ParquetSink struct {
+	w         io.Writer
+	schema    *schemaBuffer
+	pw        *parquet.Writer
+	pqColumns []string // physical column order assigned by parquet.Schema, may differ from schema.columns
+}
+
+// ParquetOption configures a ParquetSink.
+type ParquetOption func(*ParquetSink)
+
+// WithParquetSchemaPrefix overrides how many rows are scanned to infer the
+// attribute column set before the Parquet schema is fixed.
+func WithParquetSchemaPrefix(n int) ParquetOption {
+	return func(s *ParquetSink) { s.schema = newSchemaBuffer(n) }
+}
+
+// NewParquetSink returns a ParquetSink writing to w.
+func NewParquetSink(w io.Writer, opts ...ParquetOption) *ParquetSink {
+	s := &ParquetSink{w: w, schema: newSchemaBuffer(defaultSchemaPrefix)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Write implements Writer.
+func (s *ParquetSink) Write(resp client.TaskResponse) error {
+	for _, row := range flatten(resp) {
+		if s.pw != nil {
+			if err := s.writeRow(row); err != nil {
+				return err
+			}
+			continue
+		}
+		if ready := s.schema.add(row); ready {
+			if err := s.open(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// open finalizes the column order, constructs the Parquet schema and
+// writer, and flushes any buffered rows.
+func (s *ParquetSink) open() error {
+	columns, buffered := s.schema.finalize()
+
+	group := make(parquet.Group, len(columns))
+	for _, col := range columns {
+		if col == "task_id" || col == "extraction_class" || col == "extraction_text" {
+			group[col] = parquet.String()
+		} else {
+			group[col] = parquet.Optional(parquet.String())
+		}
+	}
+
+	pqSchema := parquet.NewSchema("entity", group)
+	s.pqColumns = make([]string, len(pqSchema.Columns()))
+	for i, path := range pqSchema.Columns() {
+		s.pqColumns[i] = path[0]
+	}
+
+	s.pw = parquet.NewWriter(s.w, pqSchema)
+	for _, row := range buffered {
+		if err := s.writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ParquetSink) writeRow(row flatEntity) error {
+	vals, present := valuesForColumns(s.pqColumns, row)
+	parquetRow := make(parquet.Row, len(vals))
+	for i, v := range vals {
+		defLevel := 0
+		if present[i] {
+			defLevel = 1
+		}
+		parquetRow[i] = parquet.ValueOf(v).Level(0, defLevel, i)
+	}
+	if _, err := s.pw.WriteRows([]parquet.Row{parquetRow}); err != nil {
+		return fmt.Errorf("write parquet row: %w", err)
+	}
+	return nil
+}
+
+// Close implements Writer. It finalizes the schema from whatever rows were
+// buffered (if the prefix limit was never reached) and flushes the
+// underlying Parquet writer's footer.
+func (s *ParquetSink) Close() error {
+	if s.pw == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	return s.pw.Close()
+}
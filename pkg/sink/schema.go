@@ -0,0 +1,105 @@
+package sink
+
+import "sort"
+
+// defaultSchemaPrefix is how many rows are buffered to infer the attribute
+// column set before a CSVSink or ParquetSink commits to a header/schema.
+const defaultSchemaPrefix = 500
+
+// baseColumns are the fixed, non-attribute columns emitted by every
+// columnar sink, in order.
+var baseColumns = []string{"task_id", "extraction_class", "extraction_text"}
+
+// baseColumnSet mirrors baseColumns for membership checks.
+var baseColumnSet = func() map[string]bool {
+	set := make(map[string]bool, len(baseColumns))
+	for _, c := range baseColumns {
+		set[c] = true
+	}
+	return set
+}()
+
+// schemaBuffer buffers rows until prefixLimit is reached (or the caller
+// forces a flush at Close), then infers a stable column order: the fixed
+// base columns followed by the sorted union of attribute keys seen in the
+// buffered rows.
+type schemaBuffer struct {
+	prefixLimit int
+	rows        []flatEntity
+	attrKeys    map[string]bool
+	columns     []string // set once finalized
+}
+
+func newSchemaBuffer(prefixLimit int) *schemaBuffer {
+	if prefixLimit <= 0 {
+		prefixLimit = defaultSchemaPrefix
+	}
+	return &schemaBuffer{prefixLimit: prefixLimit, attrKeys: make(map[string]bool)}
+}
+
+// add buffers row and reports whether the caller should now finalize()
+// because the prefix limit has been reached.
+func (b *schemaBuffer) add(row flatEntity) (readyToFinalize bool) {
+	b.rows = append(b.rows, row)
+	for k := range row.Attributes {
+		b.attrKeys[k] = true
+	}
+	return len(b.rows) >= b.prefixLimit
+}
+
+// finalized reports whether finalize has already run.
+func (b *schemaBuffer) finalized() bool {
+	return b.columns != nil
+}
+
+// finalize computes the stable column order from the rows buffered so far
+// and returns the buffered rows for the caller to flush. It is idempotent
+// after the first call.
+func (b *schemaBuffer) finalize() (columns []string, buffered []flatEntity) {
+	if b.finalized() {
+		return b.columns, nil
+	}
+
+	keys := make([]string, 0, len(b.attrKeys))
+	for k := range b.attrKeys {
+		if baseColumnSet[k] {
+			// An attribute sharing a name with a base column would collide
+			// with it in the column list and valuesForColumns would always
+			// resolve to the base field, silently dropping the attribute's
+			// value. Skip it rather than emit an unreadable duplicate column.
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b.columns = make([]string, 0, len(baseColumns)+len(keys))
+	b.columns = append(b.columns, baseColumns...)
+	b.columns = append(b.columns, keys...)
+
+	buffered = b.rows
+	b.rows = nil
+	return b.columns, buffered
+}
+
+// valuesForColumns returns row's fields in column order. A column with no value for
+// this row (a base field is always present; an attribute key may be
+// missing) is reported via the second return's corresponding false entry.
+func valuesForColumns(columns []string, row flatEntity) (vals []string, present []bool) {
+	vals = make([]string, len(columns))
+	present = make([]bool, len(columns))
+	for i, col := range columns {
+		switch col {
+		case "task_id":
+			vals[i], present[i] = row.TaskID, true
+		case "extraction_class":
+			vals[i], present[i] = row.ExtractionClass, true
+		case "extraction_text":
+			vals[i], present[i] = row.ExtractionText, true
+		default:
+			v, ok := row.Attributes[col]
+			vals[i], present[i] = v, ok
+		}
+	}
+	return vals, present
+}
@@ -0,0 +1,43 @@
+// Package sink writes completed LangExtract task results to a destination
+// format: newline-delimited JSON, CSV, or Parquet. CSVSink and ParquetSink
+// flatten each Entity's Attributes map into columns; since the set of
+// attribute keys varies across documents, both infer a stable column set
+// from a bounded prefix of results before writing the first row.
+package sink
+
+import "github.com/IgnatG/langextract-api/pkg/client"
+
+// Writer accepts completed TaskResponses and persists their entities to a
+// sink-specific destination. Callers must call Close to flush buffered
+// data; Close does not close the underlying io.Writer, which stays owned by
+// the caller.
+type Writer interface {
+	Write(resp client.TaskResponse) error
+	Close() error
+}
+
+// flatEntity is one Entity row, ready to be written to a columnar sink.
+type flatEntity struct {
+	TaskID          string
+	ExtractionClass string
+	ExtractionText  string
+	Attributes      map[string]string
+}
+
+// flatten extracts flatEntity rows from resp. A TaskResponse with no Result
+// (e.g. a still-pending or failed task) yields no rows.
+func flatten(resp client.TaskResponse) []flatEntity {
+	if resp.Result == nil {
+		return nil
+	}
+	rows := make([]flatEntity, 0, len(resp.Result.Entities))
+	for _, ent := range resp.Result.Entities {
+		rows = append(rows, flatEntity{
+			TaskID:          resp.TaskID,
+			ExtractionClass: ent.ExtractionClass,
+			ExtractionText:  ent.ExtractionText,
+			Attributes:      ent.Attributes,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,184 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/IgnatG/langextract-api/pkg/client"
+	"github.com/parquet-go/parquet-go"
+)
+
+func sampleResponses() []client.TaskResponse {
+	return []client.TaskResponse{
+		{
+			TaskID: "t-1",
+			State:  client.StateSuccess,
+			Result: &client.TaskResult{Entities: []client.Entity{
+				{ExtractionClass: "party", ExtractionText: "Acme Corp", Attributes: map[string]string{"role": "seller"}},
+				{ExtractionClass: "amount", ExtractionText: "$12,500"},
+			}},
+		},
+		{
+			TaskID: "t-2",
+			State:  client.StateSuccess,
+			Result: &client.TaskResult{Entities: []client.Entity{
+				{ExtractionClass: "party", ExtractionText: "Beta LLC", Attributes: map[string]string{"role": "buyer", "jurisdiction": "DE"}},
+			}},
+		},
+		{TaskID: "t-3", State: client.StateFailure, Error: "timeout"},
+	}
+}
+
+func TestJSONLSink(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewJSONLSink(&buf)
+	for _, resp := range sampleResponses() {
+		if err := s.Write(resp); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"task_id":"t-1"`) {
+		t.Errorf("line 0 = %q, want task_id t-1", lines[0])
+	}
+}
+
+func TestCSVSinkFlattensAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewCSVSink(&buf, WithSchemaPrefix(3))
+	for _, resp := range sampleResponses() {
+		if err := s.Write(resp); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	r := csv.NewReader(&buf)
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	wantHeader := []string{"task_id", "extraction_class", "extraction_text", "jurisdiction", "role"}
+	if !equalSlices(records[0], wantHeader) {
+		t.Fatalf("header = %v, want %v", records[0], wantHeader)
+	}
+	if len(records) != 4 { // header + 3 entities
+		t.Fatalf("got %d records, want 4: %v", len(records), records)
+	}
+	// Row for "Acme Corp": role=seller, jurisdiction column present but empty.
+	if records[1][4] != "seller" || records[1][3] != "" {
+		t.Errorf("row 1 = %v, want jurisdiction empty and role=seller", records[1])
+	}
+}
+
+func TestCSVSinkBelowSchemaPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewCSVSink(&buf, WithSchemaPrefix(100))
+	if err := s.Write(sampleResponses()[0]); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	r := csv.NewReader(&buf)
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 3 { // header + 2 entities
+		t.Fatalf("got %d records, want 3: %v", len(records), records)
+	}
+}
+
+func TestParquetSinkRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewParquetSink(&buf, WithParquetSchemaPrefix(2))
+	for _, resp := range sampleResponses() {
+		if err := s.Write(resp); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+	defer reader.Close()
+
+	var got []map[string]interface{}
+	for {
+		row := map[string]interface{}{}
+		if err := reader.Read(&row); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatalf("Read() error = %v", err)
+		}
+		got = append(got, row)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d rows, want 3: %+v", len(got), got)
+	}
+	if got[0]["task_id"] != "t-1" || got[0]["role"] != "seller" {
+		t.Errorf("row 0 = %+v, want task_id=t-1 role=seller", got[0])
+	}
+}
+
+func TestCSVSinkSkipsAttributeCollidingWithBaseColumn(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewCSVSink(&buf, WithSchemaPrefix(1))
+	resp := client.TaskResponse{
+		TaskID: "t-1",
+		State:  client.StateSuccess,
+		Result: &client.TaskResult{Entities: []client.Entity{
+			{ExtractionClass: "party", ExtractionText: "Acme Corp", Attributes: map[string]string{"task_id": "attr-value"}},
+		}},
+	}
+	if err := s.Write(resp); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	r := csv.NewReader(&buf)
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	wantHeader := []string{"task_id", "extraction_class", "extraction_text"}
+	if !equalSlices(records[0], wantHeader) {
+		t.Fatalf("header = %v, want %v (attribute named task_id must not duplicate the base column)", records[0], wantHeader)
+	}
+	if records[1][0] != "t-1" {
+		t.Errorf("task_id column = %q, want t-1 (not the colliding attribute value)", records[1][0])
+	}
+}
+
+func equalSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}